@@ -0,0 +1,81 @@
+// Package contract defines the request/reply types that flow between
+// tempconvert's HTTP handlers and its content negotiation layer.
+package contract
+
+import "strconv"
+
+// Message is the marker interface resolvers return so marshalers can accept
+// any type in this package through one (Message, error) signature. It
+// mirrors the legacy proto.Message shape but isn't wired into real protobuf
+// marshaling - see marshal.DefaultRegistry.
+type Message interface {
+	Reset()
+	String() string
+	ProtoMessage()
+}
+
+// TempConvertRequest is the request for the deprecated /celsius endpoint.
+type TempConvertRequest struct {
+	Fahrenheit float64 `protobuf:"fixed64,1,opt,name=fahrenheit,proto3" json:"fahrenheit,omitempty"`
+}
+
+func (*TempConvertRequest) Reset()        {}
+func (*TempConvertRequest) ProtoMessage() {}
+func (r *TempConvertRequest) String() string {
+	return strconv.FormatFloat(r.Fahrenheit, 'g', -1, 64)
+}
+
+// TempConvertReply is the reply for the deprecated /celsius endpoint.
+type TempConvertReply struct {
+	Celsius float64 `protobuf:"fixed64,1,opt,name=celsius,proto3" json:"celsius,omitempty"`
+}
+
+func (*TempConvertReply) Reset()        {}
+func (*TempConvertReply) ProtoMessage() {}
+func (r *TempConvertReply) String() string {
+	return strconv.FormatFloat(r.Celsius, 'g', -1, 64)
+}
+
+// ConvertRequest asks for Value in From to be converted to the unit named by To.
+type ConvertRequest struct {
+	From  string  `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string  `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Value float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (*ConvertRequest) Reset()         {}
+func (*ConvertRequest) ProtoMessage()  {}
+func (*ConvertRequest) String() string { return "" }
+
+// FahrenheitList is the POST body accepted by /celsius/stream: a sequence
+// of Fahrenheit values to convert and stream back one at a time.
+type FahrenheitList struct {
+	Values []float64 `protobuf:"fixed64,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (*FahrenheitList) Reset()         {}
+func (*FahrenheitList) ProtoMessage()  {}
+func (*FahrenheitList) String() string { return "" }
+
+// ConvertReply is Value converted into Unit.
+type ConvertReply struct {
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Unit  string  `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+}
+
+func (*ConvertReply) Reset()        {}
+func (*ConvertReply) ProtoMessage() {}
+func (r *ConvertReply) String() string {
+	return strconv.FormatFloat(r.Value, 'g', -1, 64) + " " + r.Unit
+}
+
+// HttpBody lets a resolver return a raw, pre-rendered response instead of a
+// message the marshaler would have to encode. Modeled on google.api.HttpBody.
+type HttpBody struct {
+	ContentType string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"contentType,omitempty"`
+	Data        []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (*HttpBody) Reset()         {}
+func (*HttpBody) ProtoMessage()  {}
+func (*HttpBody) String() string { return "" }
@@ -1,22 +1,47 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"example.com/user/tempconvert/contract"
-	"google.golang.org/protobuf/proto"
+	"example.com/user/tempconvert/marshal"
+	"example.com/user/tempconvert/metrics"
+	"example.com/user/tempconvert/resolver"
+	"example.com/user/tempconvert/router"
+)
+
+// registry is the content negotiation registry shared by every handler.
+var registry = marshal.DefaultRegistry()
+
+// unitRegistry backs /v1/convert, the general multi-family conversion
+// endpoint. /celsius and /convert stay hardcoded to temperature.
+var unitRegistry = resolver.DefaultRegistry()
+
+var (
+	errMissingFahrenheit    = errors.New("missing fahrenheit URL query param")
+	errInvalidFahrenheit    = errors.New("invalid fahrenheit value")
+	errStreamingUnsupported = errors.New("streaming not supported by the underlying ResponseWriter")
 )
 
 // GetMux returns the multiplexer - registered routes & functions
 func GetMux() http.Handler {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", helloHandler)
-	mux.HandleFunc("/celsius", celsiusHandler)
-	return mux
+	r := router.New()
+	r.HandleFunc("/", metrics.Middleware("/", helloHandler)).Methods(http.MethodGet)
+	// celsius is kept for backward compatibility; new clients should use
+	// the path-based /convert route below.
+	r.HandleFunc("/celsius", metrics.Middleware("/celsius", celsiusHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/celsius/stream", metrics.Middleware("/celsius/stream", celsiusStreamHandler)).Methods(http.MethodGet, http.MethodPost)
+	r.HandleFunc("/convert/{unit:celsius|fahrenheit|kelvin}/{value:[-+]?[0-9]*\\.?[0-9]+}", metrics.Middleware("/convert", convertHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/convert", metrics.Middleware("/v1/convert", convertV1Handler)).Methods(http.MethodPost)
+	r.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+	return r
 }
 
 func helloHandler(w http.ResponseWriter, r *http.Request) {
@@ -26,40 +51,246 @@ func helloHandler(w http.ResponseWriter, r *http.Request) {
 func celsiusHandler(w http.ResponseWriter, r *http.Request) {
 	fahrenheit, ok := r.URL.Query()["fahrenheit"]
 	if !ok {
-		http.Error(w, "missing fahrenheit URL query param", http.StatusBadRequest)
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, errMissingFahrenheit)
 		return
 	}
 	f, err := strconv.ParseFloat(fahrenheit[0], 64)
 	if err != nil {
-		http.Error(w, "invalid fahrenheit value", http.StatusBadRequest)
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, errInvalidFahrenheit)
+		return
+	}
+	reply, err := celsiusResolver(&contract.TempConvertRequest{Fahrenheit: f})
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusInternalServerError, err)
 		return
 	}
-	reply := celsiusResolver(&contract.TempConvertRequest{Fahrenheit: f})
 	body, err := celsiusMarshaller(w, r, reply)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusInternalServerError, err)
 		return
 	}
 	w.Write(body)
 }
 
-func celsiusResolver(r *contract.TempConvertRequest) *contract.TempConvertReply {
+// celsiusResolver returns a contract.Message so it shares its (request)
+// (Message, error) signature with every other resolver, including ones
+// that return a *contract.HttpBody.
+func celsiusResolver(r *contract.TempConvertRequest) (contract.Message, error) {
 	c := (r.Fahrenheit - 32) * 5 / 9
-	return &contract.TempConvertReply{Celsius: c}
+	metrics.ConversionsTotal.WithLabelValues("fahrenheit", "celsius").Inc()
+	return &contract.TempConvertReply{Celsius: c}, nil
+}
+
+// celsiusStreamHandler serves /celsius/stream, converting a list of
+// Fahrenheit values one at a time and pushing each as an SSE "data:" frame.
+func celsiusStreamHandler(w http.ResponseWriter, r *http.Request) {
+	values, err := parseStreamFahrenheits(r)
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, err)
+		return
+	}
+	interval, err := parseStreamInterval(r)
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, err)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusInternalServerError, errStreamingUnsupported)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	// The server's WriteTimeout is a fixed deadline from when headers were
+	// read; a paced stream routinely outlives it. Disable it for this
+	// response so a slow ?interval= doesn't get the connection force-closed
+	// mid-stream. Ignore the error: httptest.ResponseRecorder (used in
+	// tests) doesn't support write deadlines.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	for i, f := range values {
+		msg, err := celsiusResolver(&contract.TempConvertRequest{Fahrenheit: f})
+		if err != nil {
+			return
+		}
+		reply, ok := msg.(*contract.TempConvertReply)
+		if !ok {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", strconv.FormatFloat(reply.Celsius, 'g', -1, 64)); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		if i == len(values)-1 {
+			return
+		}
+		select {
+		case <-time.After(interval):
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// parseStreamFahrenheits reads the Fahrenheit values celsiusStreamHandler
+// should convert: ?fahrenheit= for GET, a FahrenheitList body for POST.
+func parseStreamFahrenheits(r *http.Request) ([]float64, error) {
+	if r.Method == http.MethodPost {
+		dec, err := registry.Get(r.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		var list contract.FahrenheitList
+		if err := dec.Unmarshal(data, &list); err != nil {
+			return nil, err
+		}
+		return list.Values, nil
+	}
+
+	raw := r.URL.Query().Get("fahrenheit")
+	if raw == "" {
+		return nil, errMissingFahrenheit
+	}
+	parts := strings.Split(raw, ",")
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, errInvalidFahrenheit
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// parseStreamInterval reads the optional ?interval= query param as a Go duration.
+func parseStreamInterval(r *http.Request) (time.Duration, error) {
+	raw := r.URL.Query().Get("interval")
+	if raw == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+// convertHandler serves GET /convert/{unit}/{value}, converting value from
+// unit to Celsius. It's the path-based replacement for /celsius.
+func convertHandler(w http.ResponseWriter, r *http.Request) {
+	unit, err := router.PathSegment(r, "unit")
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, err)
+		return
+	}
+	rawValue, err := router.PathSegment(r, "value")
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, err)
+		return
+	}
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, errInvalidFahrenheit)
+		return
+	}
+
+	// unit is constrained by the route's regex to one of these three values.
+	var fahrenheit float64
+	switch unit {
+	case "celsius":
+		fahrenheit = value*9/5 + 32
+	case "fahrenheit":
+		fahrenheit = value
+	case "kelvin":
+		fahrenheit = (value-273.15)*9/5 + 32
+	}
+
+	reply, err := celsiusResolver(&contract.TempConvertRequest{Fahrenheit: fahrenheit})
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusInternalServerError, err)
+		return
+	}
+	body, err := celsiusMarshaller(w, r, reply)
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusInternalServerError, err)
+		return
+	}
+	w.Write(body)
+}
+
+// celsiusMarshaller negotiates a response encoding from r's Accept header
+// and renders msg in it. With no Accept header, a *contract.TempConvertReply
+// is written as a bare text/plain number; any other message is negotiated
+// as if the header were "*/*". A *contract.HttpBody short-circuits both
+// paths, writing its ContentType and Data verbatim.
+func celsiusMarshaller(w http.ResponseWriter, r *http.Request, msg contract.Message) ([]byte, error) {
+	if body, ok := msg.(*contract.HttpBody); ok {
+		w.Header().Set("Content-Type", body.ContentType)
+		return body.Data, nil
+	}
+	if r.Header.Get("Accept") == "" {
+		if reply, ok := msg.(*contract.TempConvertReply); ok {
+			w.Header().Set("Content-Type", "text/plain")
+			return []byte(strconv.FormatFloat(reply.Celsius, 'g', -1, 64)), nil
+		}
+	}
+	return negotiateAndMarshal(w, r, msg)
+}
+
+// negotiateAndMarshal picks a response encoding from r's Accept header and
+// renders v in it, setting the matching Content-Type header.
+func negotiateAndMarshal(w http.ResponseWriter, r *http.Request, v interface{}) ([]byte, error) {
+	m, err := registry.Negotiate(r.Header.Get("Accept"))
+	if err != nil {
+		return nil, err
+	}
+	body, err := m.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	w.Header().Set("Content-Type", m.ContentType())
+	return body, nil
 }
 
-func celsiusMarshaller(w http.ResponseWriter, r *http.Request, reply *contract.TempConvertReply) ([]byte, error) {
-	accept := r.Header.Get("accept")
-	w.Header().Set("Content-Type", accept)
-	switch accept {
-	case "application/protobuf":
-		return proto.Marshal(reply)
-	case "application/json":
-		return json.Marshal(reply)
-	default:
-		w.Header().Set("Content-Type", "text/plain")
-		return []byte(strconv.FormatFloat(reply.Celsius, 'g', -1, 64)), nil
+// convertV1Handler serves POST /v1/convert. It decodes a ConvertRequest
+// from the body using whichever encoding Content-Type names, dispatches it
+// through unitRegistry, and renders the reply in whatever the Accept
+// header negotiates to.
+func convertV1Handler(w http.ResponseWriter, r *http.Request) {
+	dec, err := registry.Get(r.Header.Get("Content-Type"))
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusUnsupportedMediaType, err)
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, err)
+		return
+	}
+	var req contract.ConvertRequest
+	if err := dec.Unmarshal(data, &req); err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, err)
+		return
+	}
+
+	reply, err := unitRegistry.Convert(&req)
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusBadRequest, err)
+		return
+	}
+
+	body, err := negotiateAndMarshal(w, r, reply)
+	if err != nil {
+		marshal.DefaultErrorHandler(w, r, registry, http.StatusInternalServerError, err)
+		return
 	}
+	w.Write(body)
 }
 
 func main() {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"example.com/user/tempconvert/contract"
 )
 
 var mux http.Handler
@@ -21,6 +24,17 @@ func mockRequest(method string, url string) ([]byte, *http.Response, error) {
 	return body, resp, err
 }
 
+func mockJSONRequest(method, url, contentType string, payload []byte) ([]byte, *http.Response, error) {
+	request := httptest.NewRequest(method, url, bytes.NewReader(payload))
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("Accept", "application/json")
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	resp := recorder.Result()
+	body, err := ioutil.ReadAll(recorder.Body)
+	return body, resp, err
+}
+
 // This is a special function used to run code before and after testing runs
 func TestMain(m *testing.M) {
 	// Code here runs before testing starts
@@ -44,3 +58,100 @@ func TestConvertOk(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
 	require.Equal(t, "0", string(body))
 }
+
+func TestConvertPathFahrenheitOk(t *testing.T) {
+	body, resp, err := mockRequest("GET", "http://localhost:1234/convert/fahrenheit/32")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.Equal(t, "0", string(body))
+}
+
+func TestConvertPathKelvinOk(t *testing.T) {
+	body, resp, err := mockRequest("GET", "http://localhost:1234/convert/kelvin/273.15")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.Equal(t, "0", string(body))
+}
+
+func TestConvertPathUnknownUnitNotFound(t *testing.T) {
+	_, resp, err := mockRequest("GET", "http://localhost:1234/convert/rankine/100")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestConvertV1JSONOk(t *testing.T) {
+	body, resp, err := mockJSONRequest(
+		"POST", "http://localhost:1234/v1/convert",
+		"application/json", []byte(`{"from":"miles","to":"kilometers","value":1}`),
+	)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.JSONEq(t, `{"value":1.609344,"unit":"kilometers"}`, string(body))
+}
+
+func TestMetricsEndpointRecordsRequests(t *testing.T) {
+	_, resp, err := mockRequest("GET", "http://localhost:1234/celsius?fahrenheit=32")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, resp, err := mockRequest("GET", "http://localhost:1234/metrics")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body), `http_requests_total{code="200",method="GET",route="/celsius"}`)
+}
+
+func TestMetricsEndpointRecordsConversions(t *testing.T) {
+	_, resp, err := mockRequest("GET", "http://localhost:1234/celsius?fahrenheit=32")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, resp, err = mockRequest("GET", "http://localhost:1234/convert/kelvin/273.15")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, resp, err := mockRequest("GET", "http://localhost:1234/metrics")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body), `converter_conversions_total{from="fahrenheit",to="celsius"}`)
+}
+
+func TestConvertV1MismatchedFamilyBadRequest(t *testing.T) {
+	_, resp, err := mockJSONRequest(
+		"POST", "http://localhost:1234/v1/convert",
+		"application/json", []byte(`{"from":"celsius","to":"meters","value":1}`),
+	)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCelsiusResolverReturnsMessage(t *testing.T) {
+	msg, err := celsiusResolver(&contract.TempConvertRequest{Fahrenheit: 32})
+	require.NoError(t, err)
+	reply, ok := msg.(*contract.TempConvertReply)
+	require.True(t, ok)
+	require.Equal(t, 0.0, reply.Celsius)
+}
+
+func TestCelsiusMarshallerHttpBodyBypassesMarshaling(t *testing.T) {
+	request := httptest.NewRequest("GET", "http://localhost:1234/celsius?fahrenheit=32", nil)
+	request.Header.Set("Accept", "application/json")
+	recorder := httptest.NewRecorder()
+
+	body, err := celsiusMarshaller(recorder, request, &contract.HttpBody{
+		ContentType: "text/csv",
+		Data:        []byte("celsius\n0\n"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "celsius\n0\n", string(body))
+}
+
+func TestCelsiusHandlerMarshalErrorPropagatesTo500(t *testing.T) {
+	request := httptest.NewRequest("GET", "http://localhost:1234/celsius?fahrenheit=32", nil)
+	request.Header.Set("Accept", "application/does-not-exist")
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
+}
@@ -0,0 +1,43 @@
+package marshal
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// HTTPError is the structured error body rendered by ErrorHandler, e.g.
+// `{"code":400,"message":"missing fahrenheit URL query param"}`.
+type HTTPError struct {
+	Code    int    `json:"code" xml:"code" yaml:"code"`
+	Message string `json:"message" xml:"message" yaml:"message"`
+}
+
+// String renders the error as plain text, e.g. "400: missing fahrenheit URL
+// query param". textMarshaler uses this via fmt.Stringer instead of falling
+// back to a raw %v struct dump.
+func (e HTTPError) String() string {
+	return strconv.Itoa(e.Code) + ": " + e.Message
+}
+
+// ErrorHandler renders err as an HTTP response in the negotiated content type.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, reg *Registry, status int, err error)
+
+// DefaultErrorHandler writes err as an HTTPError in the Accept-negotiated
+// encoding, falling back to plain text if negotiation or marshaling fails.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, reg *Registry, status int, err error) {
+	body := HTTPError{Code: status, Message: err.Error()}
+
+	m, negErr := reg.Negotiate(r.Header.Get("Accept"))
+	if negErr == nil {
+		if data, marshalErr := m.Marshal(&body); marshalErr == nil {
+			w.Header().Set("Content-Type", m.ContentType())
+			w.WriteHeader(status)
+			w.Write(data)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write([]byte(body.Message))
+}
@@ -0,0 +1,74 @@
+package marshal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultErrorHandlerJSON(t *testing.T) {
+	reg := DefaultRegistry()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/json")
+
+	DefaultErrorHandler(w, r, reg, http.StatusBadRequest, errors.New("missing fahrenheit URL query param"))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"code":400,"message":"missing fahrenheit URL query param"}`, w.Body.String())
+}
+
+func TestDefaultErrorHandlerXML(t *testing.T) {
+	reg := DefaultRegistry()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/xml")
+
+	DefaultErrorHandler(w, r, reg, http.StatusInternalServerError, errors.New("boom"))
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+	require.Contains(t, w.Body.String(), "<message>boom</message>")
+}
+
+func TestDefaultErrorHandlerFallsBackToTextOnNoAcceptableType(t *testing.T) {
+	reg := DefaultRegistry()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/does-not-exist")
+
+	DefaultErrorHandler(w, r, reg, http.StatusBadRequest, errors.New("bad request"))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, "bad request", w.Body.String())
+}
+
+func TestDefaultErrorHandlerTextPlain(t *testing.T) {
+	reg := DefaultRegistry()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/plain")
+
+	DefaultErrorHandler(w, r, reg, http.StatusBadRequest, errors.New("missing fahrenheit URL query param"))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	require.Equal(t, "400: missing fahrenheit URL query param", w.Body.String())
+}
+
+func TestDefaultErrorHandlerEmptyAcceptDefaultsToWildcard(t *testing.T) {
+	reg := DefaultRegistry()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	DefaultErrorHandler(w, r, reg, http.StatusBadRequest, errors.New("bad request"))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"code":400,"message":"bad request"}`, w.Body.String())
+}
@@ -0,0 +1,170 @@
+// Package marshal picks a response encoding from an Accept header (RFC
+// 7231 q-values and wildcards) and renders success and error bodies in it.
+package marshal
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotAcceptable is returned when no registered content type satisfies the Accept header.
+var ErrNotAcceptable = errors.New("marshal: no acceptable content type")
+
+// Marshaler encodes and decodes a value for one content type.
+type Marshaler interface {
+	// ContentType is the MIME type this Marshaler produces, e.g. "application/json".
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Registry maps MIME types to Marshalers and resolves an Accept header to the best match.
+type Registry struct {
+	marshalers map[string]Marshaler
+	order      []string // registration order, for deterministic wildcard matching
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{marshalers: map[string]Marshaler{}}
+}
+
+// Register associates m with its content type, overwriting any previous Marshaler for it.
+func (reg *Registry) Register(m Marshaler) {
+	contentType := m.ContentType()
+	if _, exists := reg.marshalers[contentType]; !exists {
+		reg.order = append(reg.order, contentType)
+	}
+	reg.marshalers[contentType] = m
+}
+
+// accept is one parsed entry of an Accept header, e.g. "application/json;q=0.8".
+type accept struct {
+	mimeType string
+	q        float64
+}
+
+// parseAccept parses an Accept header into q-sorted entries; an empty header means "*/*".
+func parseAccept(header string) []accept {
+	if strings.TrimSpace(header) == "" {
+		return []accept{{mimeType: "*/*", q: 1}}
+	}
+
+	var accepts []accept
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		mimeType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mimeType == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if v, ok := strings.CutPrefix(param, "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		accepts = append(accepts, accept{mimeType: mimeType, q: q})
+	}
+
+	sort.SliceStable(accepts, func(i, j int) bool { return accepts[i].q > accepts[j].q })
+	return accepts
+}
+
+// matches reports whether contentType satisfies an Accept entry, honoring "*/*" and "type/*".
+func matches(want, contentType string) bool {
+	if want == "*/*" {
+		return true
+	}
+	if strings.HasSuffix(want, "/*") {
+		return strings.HasPrefix(contentType, strings.TrimSuffix(want, "*"))
+	}
+	return want == contentType
+}
+
+// Negotiate returns the Marshaler best satisfying header, breaking wildcard
+// ties by registration order. Returns ErrNotAcceptable if nothing matches.
+func (reg *Registry) Negotiate(header string) (Marshaler, error) {
+	for _, a := range parseAccept(header) {
+		if a.q <= 0 {
+			continue
+		}
+		if m, ok := reg.marshalers[a.mimeType]; ok {
+			return m, nil
+		}
+		for _, contentType := range reg.order {
+			if matches(a.mimeType, contentType) {
+				return reg.marshalers[contentType], nil
+			}
+		}
+	}
+	return nil, ErrNotAcceptable
+}
+
+// Get returns the Marshaler for the exact contentType (params like "; charset=..."
+// stripped), defaulting to JSON if contentType is empty. Unlike Negotiate, no wildcard matching.
+func (reg *Registry) Get(contentType string) (Marshaler, error) {
+	contentType = strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	m, ok := reg.marshalers[contentType]
+	if !ok {
+		return nil, ErrNotAcceptable
+	}
+	return m, nil
+}
+
+type jsonMarshaler struct{}
+
+func (jsonMarshaler) ContentType() string                        { return "application/json" }
+func (jsonMarshaler) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonMarshaler) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+type xmlMarshaler struct{}
+
+func (xmlMarshaler) ContentType() string                        { return "application/xml" }
+func (xmlMarshaler) Marshal(v interface{}) ([]byte, error)      { return xml.Marshal(v) }
+func (xmlMarshaler) Unmarshal(data []byte, v interface{}) error { return xml.Unmarshal(data, v) }
+
+type yamlMarshaler struct{}
+
+func (yamlMarshaler) ContentType() string                        { return "application/yaml" }
+func (yamlMarshaler) Marshal(v interface{}) ([]byte, error)      { return yaml.Marshal(v) }
+func (yamlMarshaler) Unmarshal(data []byte, v interface{}) error { return yaml.Unmarshal(data, v) }
+
+type textMarshaler struct{}
+
+func (textMarshaler) ContentType() string { return "text/plain" }
+
+func (textMarshaler) Marshal(v interface{}) ([]byte, error) {
+	if s, ok := v.(fmt.Stringer); ok {
+		return []byte(s.String()), nil
+	}
+	return []byte(fmt.Sprintf("%v", v)), nil
+}
+
+func (textMarshaler) Unmarshal([]byte, interface{}) error {
+	return errors.New("marshal: text/plain does not support decoding")
+}
+
+// DefaultRegistry returns a Registry with JSON, XML, YAML, and text/plain
+// Marshalers. No protobuf codec is registered: contract's types don't
+// implement proto.Message, so one would fail on every value in this API.
+func DefaultRegistry() *Registry {
+	reg := NewRegistry()
+	reg.Register(jsonMarshaler{})
+	reg.Register(xmlMarshaler{})
+	reg.Register(yamlMarshaler{})
+	reg.Register(textMarshaler{})
+	return reg
+}
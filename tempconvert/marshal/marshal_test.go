@@ -0,0 +1,80 @@
+package marshal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateExactMatch(t *testing.T) {
+	reg := DefaultRegistry()
+	m, err := reg.Negotiate("application/json")
+	require.NoError(t, err)
+	require.Equal(t, "application/json", m.ContentType())
+}
+
+func TestNegotiateQValuePrefersHigher(t *testing.T) {
+	reg := DefaultRegistry()
+	m, err := reg.Negotiate("application/xml;q=0.2, application/json;q=0.8")
+	require.NoError(t, err)
+	require.Equal(t, "application/json", m.ContentType())
+}
+
+func TestNegotiateWildcardSubtype(t *testing.T) {
+	reg := DefaultRegistry()
+	m, err := reg.Negotiate("application/*")
+	require.NoError(t, err)
+	require.Equal(t, "application/json", m.ContentType())
+}
+
+func TestNegotiateWildcardIsDeterministic(t *testing.T) {
+	reg := DefaultRegistry()
+	for i := 0; i < 50; i++ {
+		m, err := reg.Negotiate("application/*")
+		require.NoError(t, err)
+		require.Equal(t, "application/json", m.ContentType())
+	}
+}
+
+func TestNegotiateFullWildcard(t *testing.T) {
+	reg := DefaultRegistry()
+	m, err := reg.Negotiate("*/*")
+	require.NoError(t, err)
+	require.Equal(t, "application/json", m.ContentType())
+}
+
+func TestNegotiateWildcardPrefersRegistrationOrder(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(xmlMarshaler{})
+	reg.Register(jsonMarshaler{})
+	m, err := reg.Negotiate("application/*")
+	require.NoError(t, err)
+	require.Equal(t, "application/xml", m.ContentType())
+}
+
+func TestNegotiateEmptyHeaderDefaultsToWildcard(t *testing.T) {
+	reg := DefaultRegistry()
+	m, err := reg.Negotiate("")
+	require.NoError(t, err)
+	require.Equal(t, "application/json", m.ContentType())
+}
+
+func TestNegotiateNoAcceptableType(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(jsonMarshaler{})
+	_, err := reg.Negotiate("application/xml")
+	require.ErrorIs(t, err, ErrNotAcceptable)
+}
+
+func TestNegotiateSkipsZeroQValue(t *testing.T) {
+	reg := DefaultRegistry()
+	_, err := reg.Negotiate("application/json;q=0")
+	require.ErrorIs(t, err, ErrNotAcceptable)
+}
+
+func TestJSONMarshaler(t *testing.T) {
+	m := jsonMarshaler{}
+	body, err := m.Marshal(map[string]int{"a": 1})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a":1}`, string(body))
+}
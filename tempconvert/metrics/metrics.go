@@ -0,0 +1,92 @@
+// Package metrics wires Prometheus instrumentation into tempconvert's HTTP handlers.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// RequestsTotal counts completed HTTP requests by route, method, and status code.
+	RequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests by route, method, and status code.",
+	}, []string{"route", "method", "code"})
+
+	// RequestDuration observes handler latency by route and method.
+	RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds by route and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	// ResponseSize observes response body size in bytes by route and method.
+	ResponseSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_response_size_bytes",
+		Help:    "HTTP response size in bytes by route and method.",
+		Buckets: prometheus.ExponentialBuckets(64, 4, 8),
+	}, []string{"route", "method"})
+
+	// ConversionsTotal counts unit conversions performed, by source and target unit.
+	ConversionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "converter_conversions_total",
+		Help: "Total unit conversions performed, by source and target unit.",
+	}, []string{"from", "to"})
+)
+
+// Handler serves the Prometheus text exposition format for /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// responseRecorder captures the status code and byte count a handler writes.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Flush delegates to the underlying ResponseWriter's http.Flusher, if it has one.
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying ResponseWriter so http.ResponseController
+// can see past this wrapper to the conn-level deadline support it needs.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Middleware wraps next so every call records RequestsTotal, RequestDuration,
+// and ResponseSize. route should be a low-cardinality template (e.g. "/convert"),
+// not the raw request path.
+func Middleware(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next(rec, r)
+		duration := time.Since(start).Seconds()
+
+		RequestsTotal.WithLabelValues(route, r.Method, strconv.Itoa(rec.status)).Inc()
+		RequestDuration.WithLabelValues(route, r.Method).Observe(duration)
+		ResponseSize.WithLabelValues(route, r.Method).Observe(float64(rec.bytes))
+	}
+}
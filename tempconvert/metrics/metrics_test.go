@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddlewareRecordsRequestsTotal(t *testing.T) {
+	handler := Middleware("/hello", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	before := testutil.ToFloat64(RequestsTotal.WithLabelValues("/hello", http.MethodGet, "200"))
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/hello", nil))
+	after := testutil.ToFloat64(RequestsTotal.WithLabelValues("/hello", http.MethodGet, "200"))
+
+	if after != before+1 {
+		t.Errorf("RequestsTotal = %v, want %v", after, before+1)
+	}
+}
+
+func TestMiddlewareRecordsDefaultStatusOnImplicit200(t *testing.T) {
+	handler := Middleware("/implicit", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok")) // never calls WriteHeader
+	})
+
+	before := testutil.ToFloat64(RequestsTotal.WithLabelValues("/implicit", http.MethodGet, "200"))
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/implicit", nil))
+	after := testutil.ToFloat64(RequestsTotal.WithLabelValues("/implicit", http.MethodGet, "200"))
+
+	if after != before+1 {
+		t.Errorf("RequestsTotal = %v, want %v", after, before+1)
+	}
+}
+
+func TestMiddlewarePreservesFlusher(t *testing.T) {
+	handler := Middleware("/stream", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected the wrapped ResponseWriter to implement http.Flusher")
+		}
+		w.Write([]byte("ok"))
+		flusher.Flush()
+	})
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/stream", nil))
+}
+
+func TestHandlerServesMetrics(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "http_requests_total") {
+		t.Errorf("expected /metrics output to include http_requests_total")
+	}
+}
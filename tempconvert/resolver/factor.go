@@ -0,0 +1,39 @@
+package resolver
+
+import (
+	"fmt"
+
+	"example.com/user/tempconvert/contract"
+)
+
+// factorConverter converts units that are a fixed multiple of a common base unit.
+type factorConverter struct {
+	family       string
+	toBaseFactor map[string]float64
+}
+
+func newFactorConverter(family string, toBaseFactor map[string]float64) factorConverter {
+	return factorConverter{family: family, toBaseFactor: toBaseFactor}
+}
+
+func (c factorConverter) Family() string { return c.family }
+
+func (c factorConverter) Units() []string {
+	units := make([]string, 0, len(c.toBaseFactor))
+	for unit := range c.toBaseFactor {
+		units = append(units, unit)
+	}
+	return units
+}
+
+func (c factorConverter) Convert(req *contract.ConvertRequest) (*contract.ConvertReply, error) {
+	fromFactor, ok := c.toBaseFactor[req.From]
+	if !ok {
+		return nil, fmt.Errorf("resolver: unknown %s unit %q", c.family, req.From)
+	}
+	toFactor, ok := c.toBaseFactor[req.To]
+	if !ok {
+		return nil, fmt.Errorf("resolver: unknown %s unit %q", c.family, req.To)
+	}
+	return &contract.ConvertReply{Value: req.Value * fromFactor / toFactor, Unit: req.To}, nil
+}
@@ -0,0 +1,87 @@
+// Package resolver converts a value from one unit to another within a single
+// family (temperature, length, mass, time), dispatched through a Registry.
+package resolver
+
+import (
+	"fmt"
+
+	"example.com/user/tempconvert/contract"
+	"example.com/user/tempconvert/metrics"
+)
+
+// UnitConverter converts values between the units of a single family.
+type UnitConverter interface {
+	// Family names the unit family this converter handles, e.g. "temperature".
+	Family() string
+	// Units lists the unit names this converter accepts as From/To.
+	Units() []string
+	Convert(req *contract.ConvertRequest) (*contract.ConvertReply, error)
+}
+
+// Registry dispatches a ConvertRequest to the UnitConverter whose Units
+// include req.From, and rejects conversions across unit families.
+type Registry struct {
+	byUnit map[string]UnitConverter
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{byUnit: map[string]UnitConverter{}}
+}
+
+// Register makes c available for each of the units it reports from Units.
+func (reg *Registry) Register(c UnitConverter) {
+	for _, unit := range c.Units() {
+		reg.byUnit[unit] = c
+	}
+}
+
+// Convert resolves req.From to a registered UnitConverter and converts to
+// req.To, returning an error if either unit is unknown or they belong to
+// different families.
+func (reg *Registry) Convert(req *contract.ConvertRequest) (*contract.ConvertReply, error) {
+	from, ok := reg.byUnit[req.From]
+	if !ok {
+		return nil, fmt.Errorf("resolver: unknown unit %q", req.From)
+	}
+	to, ok := reg.byUnit[req.To]
+	if !ok {
+		return nil, fmt.Errorf("resolver: unknown unit %q", req.To)
+	}
+	if from.Family() != to.Family() {
+		return nil, fmt.Errorf("resolver: %q and %q are not the same unit family", req.From, req.To)
+	}
+	reply, err := from.Convert(req)
+	if err != nil {
+		return nil, err
+	}
+	metrics.ConversionsTotal.WithLabelValues(req.From, req.To).Inc()
+	return reply, nil
+}
+
+// DefaultRegistry returns a Registry with the unit families tempconvert
+// supports out of the box: temperature, length, mass, and time.
+func DefaultRegistry() *Registry {
+	reg := NewRegistry()
+	reg.Register(temperatureConverter{})
+	reg.Register(newFactorConverter("length", map[string]float64{
+		"meters":      1,
+		"kilometers":  1000,
+		"centimeters": 0.01,
+		"miles":       1609.344,
+		"feet":        0.3048,
+	}))
+	reg.Register(newFactorConverter("mass", map[string]float64{
+		"kilograms": 1,
+		"grams":     0.001,
+		"pounds":    0.45359237,
+		"ounces":    0.028349523125,
+	}))
+	reg.Register(newFactorConverter("time", map[string]float64{
+		"seconds": 1,
+		"minutes": 60,
+		"hours":   3600,
+		"days":    86400,
+	}))
+	return reg
+}
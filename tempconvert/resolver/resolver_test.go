@@ -0,0 +1,80 @@
+package resolver
+
+import (
+	"math"
+	"testing"
+
+	"example.com/user/tempconvert/contract"
+)
+
+func convert(t *testing.T, reg *Registry, from, to string, value float64) float64 {
+	t.Helper()
+	reply, err := reg.Convert(&contract.ConvertRequest{From: from, To: to, Value: value})
+	if err != nil {
+		t.Fatalf("Convert(%s->%s, %v): %v", from, to, value, err)
+	}
+	return reply.Value
+}
+
+func TestConvertTemperature(t *testing.T) {
+	reg := DefaultRegistry()
+	if got := convert(t, reg, "fahrenheit", "celsius", 32); math.Abs(got) > 1e-9 {
+		t.Errorf("32F->C = %v, want 0", got)
+	}
+	if got := convert(t, reg, "celsius", "kelvin", 0); math.Abs(got-273.15) > 1e-9 {
+		t.Errorf("0C->K = %v, want 273.15", got)
+	}
+}
+
+func TestConvertLength(t *testing.T) {
+	reg := DefaultRegistry()
+	if got := convert(t, reg, "kilometers", "meters", 1); got != 1000 {
+		t.Errorf("1km->m = %v, want 1000", got)
+	}
+}
+
+func TestConvertMismatchedFamilyErrors(t *testing.T) {
+	reg := DefaultRegistry()
+	_, err := reg.Convert(&contract.ConvertRequest{From: "celsius", To: "meters", Value: 1})
+	if err == nil {
+		t.Fatal("expected an error converting across unit families")
+	}
+}
+
+func TestConvertUnknownUnitErrors(t *testing.T) {
+	reg := DefaultRegistry()
+	_, err := reg.Convert(&contract.ConvertRequest{From: "celsius", To: "parsecs", Value: 1})
+	if err == nil {
+		t.Fatal("expected an error converting to an unknown unit")
+	}
+}
+
+func TestRoundTripCelsiusFahrenheit(t *testing.T) {
+	reg := DefaultRegistry()
+	for _, c := range []float64{-273.15, -40, 0, 37, 100, 1000.5} {
+		f := convert(t, reg, "celsius", "fahrenheit", c)
+		back := convert(t, reg, "fahrenheit", "celsius", f)
+		if math.Abs(back-c) > 1e-9 {
+			t.Errorf("round trip drifted: %v -> %v -> %v", c, f, back)
+		}
+	}
+}
+
+func FuzzConvertTemperatureRoundTrip(f *testing.F) {
+	for _, seed := range []float64{0, -40, 212, 98.6, -459.67} {
+		f.Add(seed)
+	}
+	reg := DefaultRegistry()
+	f.Fuzz(func(t *testing.T, fahrenheit float64) {
+		if math.IsNaN(fahrenheit) || math.IsInf(fahrenheit, 0) {
+			t.Skip()
+		}
+		celsius := convert(t, reg, "fahrenheit", "celsius", fahrenheit)
+		back := convert(t, reg, "celsius", "fahrenheit", celsius)
+
+		tolerance := 1e-6 * math.Max(1, math.Abs(fahrenheit))
+		if math.Abs(back-fahrenheit) > tolerance {
+			t.Errorf("round trip drifted: %v -> %v -> %v", fahrenheit, celsius, back)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package resolver
+
+import (
+	"fmt"
+
+	"example.com/user/tempconvert/contract"
+)
+
+// temperatureConverter converts among Celsius, Fahrenheit, Kelvin, and Rankine via Kelvin.
+type temperatureConverter struct{}
+
+func (temperatureConverter) Family() string { return "temperature" }
+
+func (temperatureConverter) Units() []string {
+	return []string{"celsius", "fahrenheit", "kelvin", "rankine"}
+}
+
+func (temperatureConverter) Convert(req *contract.ConvertRequest) (*contract.ConvertReply, error) {
+	kelvin, err := toKelvin(req.From, req.Value)
+	if err != nil {
+		return nil, err
+	}
+	value, err := fromKelvin(req.To, kelvin)
+	if err != nil {
+		return nil, err
+	}
+	return &contract.ConvertReply{Value: value, Unit: req.To}, nil
+}
+
+func toKelvin(unit string, v float64) (float64, error) {
+	switch unit {
+	case "celsius":
+		return v + 273.15, nil
+	case "fahrenheit":
+		return (v-32)*5/9 + 273.15, nil
+	case "kelvin":
+		return v, nil
+	case "rankine":
+		return v * 5 / 9, nil
+	default:
+		return 0, fmt.Errorf("resolver: unknown temperature unit %q", unit)
+	}
+}
+
+func fromKelvin(unit string, k float64) (float64, error) {
+	switch unit {
+	case "celsius":
+		return k - 273.15, nil
+	case "fahrenheit":
+		return (k-273.15)*9/5 + 32, nil
+	case "kelvin":
+		return k, nil
+	case "rankine":
+		return k * 9 / 5, nil
+	default:
+		return 0, fmt.Errorf("resolver: unknown temperature unit %q", unit)
+	}
+}
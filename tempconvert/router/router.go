@@ -0,0 +1,90 @@
+// Package router builds the tempconvert HTTP mux on top of gorilla/mux, adding
+// a middleware chain (recovery, request IDs, logging) and percent-decoded path segments.
+package router
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "requestID"
+
+// New returns a gorilla/mux Router with the standard middleware chain
+// installed and encoded-path preservation on, so PathSegment can unescape
+// each variable itself.
+func New() *mux.Router {
+	r := mux.NewRouter()
+	r.UseEncodedPath()
+	r.Use(RecoveryMiddleware, RequestIDMiddleware, LoggingMiddleware)
+	return r
+}
+
+// RecoveryMiddleware turns a panicking handler into a 500 instead of crashing the server.
+func RecoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Printf("recovered from panic: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequestIDMiddleware assigns a per-request ID, echoing an inbound X-Request-Id
+// header if set, and stores it in the request context.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-Id")
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set("X-Request-Id", id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey, id)))
+	})
+}
+
+// RequestID returns the request ID stored by RequestIDMiddleware, or the
+// empty string if none was set.
+func RequestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDKey).(string)
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// LoggingMiddleware logs the method, path, request ID, and latency of
+// every request.
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		log.Printf("%s %s id=%s took=%s", r.Method, r.URL.Path, RequestID(r), time.Since(start))
+	})
+}
+
+// PathSegment returns the named path variable from r, percent-decoded on its
+// own so values containing "+", "%2F", or unicode escapes round-trip correctly.
+func PathSegment(r *http.Request, name string) (string, error) {
+	raw, ok := mux.Vars(r)[name]
+	if !ok {
+		return "", nil
+	}
+	return url.PathUnescape(raw)
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathSegmentUnescapesPlus(t *testing.T) {
+	r := New()
+	var got string
+	r.HandleFunc("/segment/{value}", func(w http.ResponseWriter, req *http.Request) {
+		var err error
+		got, err = PathSegment(req, "value")
+		require.NoError(t, err)
+	})
+
+	req := httptest.NewRequest("GET", "/segment/1%2B2", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "1+2", got)
+}
+
+func TestPathSegmentUnescapesEncodedSlash(t *testing.T) {
+	r := New()
+	var got string
+	r.HandleFunc("/segment/{value}", func(w http.ResponseWriter, req *http.Request) {
+		var err error
+		got, err = PathSegment(req, "value")
+		require.NoError(t, err)
+	})
+
+	req := httptest.NewRequest("GET", "/segment/a%2Fb", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "a/b", got)
+}
+
+func TestPathSegmentUnescapesUnicode(t *testing.T) {
+	r := New()
+	var got string
+	r.HandleFunc("/segment/{value}", func(w http.ResponseWriter, req *http.Request) {
+		var err error
+		got, err = PathSegment(req, "value")
+		require.NoError(t, err)
+	})
+
+	req := httptest.NewRequest("GET", "/segment/%C2%B0C", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "°C", got)
+}
+
+func TestPathSegmentMissingVar(t *testing.T) {
+	req := httptest.NewRequest("GET", "/segment/x", nil)
+	req = mux.SetURLVars(req, map[string]string{})
+	got, err := PathSegment(req, "value")
+	require.NoError(t, err)
+	require.Equal(t, "", got)
+}
+
+func TestRequestIDMiddlewareEchoesInbound(t *testing.T) {
+	r := New()
+	r.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-Id", "inbound-id")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, "inbound-id", rec.Header().Get("X-Request-Id"))
+}
+
+func TestRecoveryMiddlewareReturns500(t *testing.T) {
+	r := New()
+	r.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// splitSSEFrames is a bufio.SplitFunc that tokenizes on the blank line
+// separating SSE frames ("\n\n"), matching the format celsiusStreamHandler
+// writes.
+func splitSSEFrames(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.Index(data, []byte("\n\n")); i >= 0 {
+		return i + 2, bytes.TrimRight(data[:i], "\r\n"), nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func TestCelsiusStreamOrdering(t *testing.T) {
+	server := httptest.NewServer(GetMux())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/celsius/stream?fahrenheit=32,212")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Split(splitSSEFrames)
+
+	var frames []string
+	for scanner.Scan() {
+		frames = append(frames, strings.TrimPrefix(scanner.Text(), "data: "))
+	}
+	require.Equal(t, []string{"0", "100"}, frames)
+}
+
+func TestCelsiusStreamCancelStopsEarly(t *testing.T) {
+	server := httptest.NewServer(GetMux())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/celsius/stream?fahrenheit=32,50,212&interval=50ms", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	buf := make([]byte, 64)
+	n, err := resp.Body.Read(buf)
+	require.NoError(t, err)
+	require.Contains(t, string(buf[:n]), "data: 0")
+
+	cancel()
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.Error(t, err)
+}
+
+// TestCelsiusStreamOutlivesServerWriteTimeout pins WriteTimeout well below
+// the stream's total duration, mirroring main()'s http.Server config, and
+// checks celsiusStreamHandler still delivers every frame instead of being
+// cut off mid-stream.
+func TestCelsiusStreamOutlivesServerWriteTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &http.Server{Handler: GetMux(), WriteTimeout: 150 * time.Millisecond}
+	defer s.Close()
+	go s.Serve(ln)
+
+	url := fmt.Sprintf("http://%s/celsius/stream?fahrenheit=32,50,212&interval=100ms", ln.Addr())
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Split(splitSSEFrames)
+
+	var frames []string
+	for scanner.Scan() {
+		frames = append(frames, strings.TrimPrefix(scanner.Text(), "data: "))
+	}
+	require.Equal(t, []string{"0", "10", "100"}, frames)
+}
+
+func TestCelsiusStreamPostJSONOk(t *testing.T) {
+	body, resp, err := mockJSONRequest(
+		"POST", "http://localhost:1234/celsius/stream",
+		"application/json", []byte(`{"values":[32,212]}`),
+	)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "data: 0\n\ndata: 100\n\n", string(body))
+}